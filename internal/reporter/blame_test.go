@@ -0,0 +1,22 @@
+package reporter_test
+
+import "fmt"
+
+// blameLine renders a single hunk of `git blame --line-porcelain` output for
+// one line of a file, enough for the reporters under test to resolve which
+// commit last touched a given line.
+func blameLine(sha string, line int, path, content string) string {
+	return fmt.Sprintf(`%s 1 %d 1
+author Test User
+author-mail <test@example.com>
+author-time 1700000000
+author-tz +0000
+committer Test User
+committer-mail <test@example.com>
+committer-time 1700000000
+committer-tz +0000
+summary test commit
+filename %s
+	%s
+`, sha, line, path, content)
+}
@@ -0,0 +1,169 @@
+package reporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/rs/zerolog/log"
+
+	"github.com/cloudflare/pint/internal/git"
+)
+
+var blameHeaderRe = regexp.MustCompile(`^([0-9a-f]{7,40}) \d+ (\d+)`)
+
+// blameSHAs parses the output of `git blame --line-porcelain` and returns the
+// commit SHA that last touched each final line number in the blamed file.
+func blameSHAs(output []byte) map[int]string {
+	shas := make(map[int]string)
+	for _, line := range strings.Split(string(output), "\n") {
+		m := blameHeaderRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		lineNo, err := strconv.Atoi(m[2])
+		if err != nil {
+			continue
+		}
+		shas[lineNo] = m[1]
+	}
+	return shas
+}
+
+// GithubReporter submits check results as a single pull request review on
+// GitHub, with one inline comment per problem. The review, and so every
+// comment in it, is anchored to the current HEAD commit; git blame is only
+// used to skip lines it can't resolve.
+type GithubReporter struct {
+	client  *github.Client
+	timeout time.Duration
+	owner   string
+	repo    string
+	prNum   int
+	gitCmd  git.CommandRunner
+}
+
+// GithubReporterOption customizes retry behaviour on a GithubReporter. The
+// defaults (retry up to four times, half a second base delay capped at
+// thirty seconds) are sane for CI use and only need overriding in tests or
+// when talking to a GitHub Enterprise instance with different limits.
+type GithubReporterOption func(*retryTransport)
+
+// WithMaxRetries caps how many times a single GitHub API call is retried on
+// a transient error or rate limit response before giving up.
+func WithMaxRetries(n int) GithubReporterOption {
+	return func(rt *retryTransport) { rt.maxRetries = n }
+}
+
+// WithRetryBaseDelay sets the delay before the first retry; subsequent
+// retries back off exponentially from it.
+func WithRetryBaseDelay(d time.Duration) GithubReporterOption {
+	return func(rt *retryTransport) { rt.baseDelay = d }
+}
+
+// WithRetryMaxDelay caps the exponential backoff delay between retries,
+// regardless of how many attempts have already been made. Up to 50% jitter is
+// added on top of the capped delay, and waits taken from Retry-After or
+// X-RateLimit-Reset headers are not capped.
+func WithRetryMaxDelay(d time.Duration) GithubReporterOption {
+	return func(rt *retryTransport) { rt.maxDelay = d }
+}
+
+// NewGithubReporter creates a new GithubReporter. baseURL and uploadURL are
+// only needed when talking to a GitHub Enterprise instance, pass nil for
+// github.com. GitHub API calls made by the reporter are retried
+// automatically on 5xx responses, network errors, and rate limiting; use the
+// With* options to tune that behaviour.
+func NewGithubReporter(baseURL, uploadURL *string, timeout time.Duration, token, owner, repo string, prNum int, gitCmd git.CommandRunner, opts ...GithubReporterOption) *GithubReporter {
+	rt := &retryTransport{
+		maxRetries: defaultMaxRetries,
+		baseDelay:  defaultRetryBaseDelay,
+		maxDelay:   defaultRetryMaxDelay,
+	}
+	for _, opt := range opts {
+		opt(rt)
+	}
+	httpClient := &http.Client{Transport: rt}
+
+	client := github.NewClient(httpClient).WithAuthToken(token)
+	if baseURL != nil && uploadURL != nil {
+		var err error
+		client, err = client.WithEnterpriseURLs(*baseURL, *uploadURL)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to create GitHub Enterprise client, falling back to github.com")
+			client = github.NewClient(httpClient).WithAuthToken(token)
+		}
+	}
+	return &GithubReporter{
+		client:  client,
+		timeout: timeout,
+		owner:   owner,
+		repo:    repo,
+		prNum:   prNum,
+		gitCmd:  gitCmd,
+	}
+}
+
+// Name implements Reporter.
+func (gr GithubReporter) Name() string {
+	return "github"
+}
+
+// Submit posts a single pull request review with one comment per problem in
+// the summary.
+func (gr GithubReporter) Submit(summary Summary) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gr.timeout)
+	defer cancel()
+
+	headCommit, err := gr.gitCmd("rev-parse", "HEAD")
+	if err != nil {
+		return fmt.Errorf("failed to get the current commit: %w", err)
+	}
+	commitID := strings.TrimSpace(string(headCommit))
+
+	var comments []*github.DraftReviewComment
+	for _, report := range summary.Reports {
+		if len(report.Problem.Lines) == 0 {
+			continue
+		}
+		blame, err := gr.gitCmd("blame", "--line-porcelain", report.Path)
+		if err != nil {
+			log.Error().Err(err).Str("path", report.Path).Msg("Failed to blame file, skipping comment")
+			continue
+		}
+		shas := blameSHAs(blame)
+		line := report.Problem.Lines[len(report.Problem.Lines)-1]
+		if _, ok := shas[line]; !ok {
+			continue
+		}
+
+		body := commentBody(report)
+		path := report.Path
+		comments = append(comments, &github.DraftReviewComment{
+			Path: &path,
+			Line: github.Int(line),
+			Body: &body,
+		})
+	}
+
+	event := "COMMENT"
+	_, _, err = gr.client.PullRequests.CreateReview(ctx, gr.owner, gr.repo, gr.prNum, &github.PullRequestReviewRequest{
+		CommitID: &commitID,
+		Event:    &event,
+		Comments: comments,
+	})
+	if err != nil {
+		if ctx.Err() != nil || errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("creating review: %w", context.DeadlineExceeded)
+		}
+		return fmt.Errorf("creating review: %w", err)
+	}
+
+	return nil
+}
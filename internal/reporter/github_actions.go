@@ -0,0 +1,149 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+// GithubActionsReporter writes pint problems to w as GitHub Actions workflow
+// commands, so they show up as native file annotations in the Checks UI
+// instead of being posted via the GitHub API. Unlike GithubReporter it
+// doesn't need a token or network access, which makes it the right choice
+// for forked-PR workflows where API credentials aren't available.
+//
+// Exposing this as `--reporter=github-actions` is still open: it belongs in
+// cmd/pint's flag parsing and reporter selection, which this change doesn't
+// touch. Until that's wired up, callers have to construct a
+// GithubActionsReporter directly.
+type GithubActionsReporter struct {
+	w           io.Writer
+	summaryPath string
+}
+
+// NewGithubActionsReporter creates a new GithubActionsReporter writing
+// workflow commands to w. If the GITHUB_STEP_SUMMARY environment variable is
+// set, a markdown summary table is also appended to the file it points at.
+func NewGithubActionsReporter(w io.Writer) *GithubActionsReporter {
+	return &GithubActionsReporter{
+		w:           w,
+		summaryPath: os.Getenv("GITHUB_STEP_SUMMARY"),
+	}
+}
+
+// workflowCommand returns the `::error|warning|notice ...::message` command
+// for a single report, per
+// https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#setting-an-error-message
+func workflowCommand(report Report) string {
+	command := "notice"
+	switch report.Problem.Severity {
+	case checks.Fatal, checks.Bug:
+		command = "error"
+	case checks.Warning:
+		command = "warning"
+	case checks.Information:
+		command = "notice"
+	}
+
+	line := 0
+	if len(report.Problem.Lines) > 0 {
+		line = report.Problem.Lines[len(report.Problem.Lines)-1]
+	}
+
+	return fmt.Sprintf(
+		"::%s file=%s,line=%d,col=1,title=%s::%s",
+		command,
+		escapeCommandProperty(report.Path),
+		line,
+		escapeCommandProperty(report.Problem.Reporter),
+		escapeCommandData(report.Problem.Text),
+	)
+}
+
+// escapeCommandData escapes the data portion of a workflow command, see
+// https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#about-workflow-commands
+func escapeCommandData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	s = strings.ReplaceAll(s, "\n", "%0A")
+	return s
+}
+
+// escapeCommandProperty escapes a property value of a workflow command, which
+// additionally can't contain unescaped commas or colons.
+func escapeCommandProperty(s string) string {
+	s = escapeCommandData(s)
+	s = strings.ReplaceAll(s, ",", "%2C")
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return s
+}
+
+// Name implements Reporter.
+func (gar GithubActionsReporter) Name() string {
+	return "github-actions"
+}
+
+// Submit prints one workflow command per problem, grouped by file so the
+// logs stay readable, and appends a markdown summary table to
+// GITHUB_STEP_SUMMARY when it's set.
+func (gar GithubActionsReporter) Submit(summary Summary) error {
+	byFile := make(map[string][]Report)
+	var files []string
+	for _, report := range summary.Reports {
+		if _, ok := byFile[report.Path]; !ok {
+			files = append(files, report.Path)
+		}
+		byFile[report.Path] = append(byFile[report.Path], report)
+	}
+	sort.Strings(files)
+
+	for _, path := range files {
+		fmt.Fprintf(gar.w, "::group::%s\n", path)
+		for _, report := range byFile[path] {
+			fmt.Fprintln(gar.w, workflowCommand(report))
+		}
+		fmt.Fprintln(gar.w, "::endgroup::")
+	}
+
+	if gar.summaryPath != "" {
+		if err := gar.writeSummary(files, byFile); err != nil {
+			return fmt.Errorf("writing step summary: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (gar GithubActionsReporter) writeSummary(files []string, byFile map[string][]Report) error {
+	f, err := os.OpenFile(gar.summaryPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Error().Err(err).Str("path", gar.summaryPath).Msg("Failed to close step summary file")
+		}
+	}()
+
+	fmt.Fprintln(f, "## pint results")
+	fmt.Fprintln(f, "| File | Reporter | Severity | Line | Problem |")
+	fmt.Fprintln(f, "| --- | --- | --- | --- | --- |")
+	for _, path := range files {
+		for _, report := range byFile[path] {
+			line := 0
+			if len(report.Problem.Lines) > 0 {
+				line = report.Problem.Lines[len(report.Problem.Lines)-1]
+			}
+			fmt.Fprintf(f, "| %s | %s | %s | %d | %s |\n",
+				path, report.Problem.Reporter, report.Problem.Severity, line, report.Problem.Text)
+		}
+	}
+
+	return nil
+}
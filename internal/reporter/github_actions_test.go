@@ -0,0 +1,96 @@
+package reporter_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/reporter"
+	"github.com/rs/zerolog"
+)
+
+func TestGithubActionsReporter(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+- record: target is down
+  expr: up == 0
+- record: sum errors
+  expr: sum(errors) by (job)
+`))
+
+	summary := reporter.Summary{
+		Reports: []reporter.Report{
+			{
+				Path: "foo.txt",
+				Rule: mockRules[1],
+				Problem: checks.Problem{
+					Fragment: "up == 0",
+					Lines:    []int{2},
+					Reporter: "mock",
+					Text:     "100% of the time this fails",
+					Severity: checks.Fatal,
+				},
+			},
+			{
+				Path: "foo.txt",
+				Rule: mockRules[1],
+				Problem: checks.Problem{
+					Fragment: "sum(errors) by (job)",
+					Lines:    []int{6},
+					Reporter: "mock",
+					Text:     "missing unit",
+					Severity: checks.Warning,
+				},
+			},
+		},
+		FileChanges: discovery.NewFileCommitsFromMap(map[string][]string{"foo.txt": {"fake-commit-id"}}),
+	}
+
+	t.Run("writes workflow commands", func(t *testing.T) {
+		var buf bytes.Buffer
+		r := reporter.NewGithubActionsReporter(&buf)
+		if err := r.Submit(summary); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		out := buf.String()
+		want := []string{
+			"::group::foo.txt",
+			"::error file=foo.txt,line=2,col=1,title=mock::100%25 of the time this fails",
+			"::warning file=foo.txt,line=6,col=1,title=mock::missing unit",
+			"::endgroup::",
+		}
+		for _, line := range want {
+			if !strings.Contains(out, line) {
+				t.Errorf("expected output to contain %q, got:\n%s", line, out)
+			}
+		}
+	})
+
+	t.Run("appends a step summary when GITHUB_STEP_SUMMARY is set", func(t *testing.T) {
+		dir := t.TempDir()
+		summaryPath := filepath.Join(dir, "summary.md")
+		t.Setenv("GITHUB_STEP_SUMMARY", summaryPath)
+
+		var buf bytes.Buffer
+		r := reporter.NewGithubActionsReporter(&buf)
+		if err := r.Submit(summary); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		content, err := os.ReadFile(summaryPath)
+		if err != nil {
+			t.Fatalf("failed to read step summary: %s", err)
+		}
+		if !strings.Contains(string(content), "foo.txt") {
+			t.Errorf("expected step summary to mention foo.txt, got:\n%s", string(content))
+		}
+	})
+}
@@ -0,0 +1,204 @@
+package reporter
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultMaxRetries     = 4
+	defaultRetryBaseDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay  = 30 * time.Second
+)
+
+// retryTransport wraps an http.RoundTripper and retries requests that fail
+// with a 5xx status, a network error, or a GitHub rate limit response,
+// backing off exponentially between attempts. It never retries past the
+// deadline on the request's context, so the overall Submit timeout still
+// caps total wall time. Each attempt additionally gets its own slice of
+// whatever time is left, split evenly across the attempts still available,
+// so a single stalled call can't eat the entire budget and starve the
+// retries it's there to enable.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+// cancelOnCloseBody releases an attempt's per-attempt context once the
+// caller is done reading the response body, rather than as soon as
+// RoundTrip returns, so a successful read is never cut short.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// attemptContext derives a context for a single attempt out of parent,
+// capped at whatever fraction of the remaining deadline is left for the
+// attempts that haven't run yet. If parent has no deadline the attempt just
+// inherits it unmodified.
+func attemptContext(parent context.Context, attempt, maxRetries int) (context.Context, context.CancelFunc) {
+	deadline, ok := parent.Deadline()
+	if !ok {
+		return parent, func() {}
+	}
+
+	remainingAttempts := maxRetries - attempt + 1
+	if remainingAttempts < 1 {
+		remainingAttempts = 1
+	}
+	budget := time.Until(deadline) / time.Duration(remainingAttempts)
+
+	return context.WithTimeout(parent, budget)
+}
+
+func (rt *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := rt.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		if err := req.Context().Err(); err != nil {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, err
+		}
+
+		attemptCtx, cancel := attemptContext(req.Context(), attempt, rt.maxRetries)
+		attemptReq := req.Clone(attemptCtx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+
+		resp, err := base.RoundTrip(attemptReq)
+		if err == nil && !shouldRetryResponse(resp) {
+			resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+
+		giveUp := attempt >= rt.maxRetries
+		if err != nil {
+			lastErr = err
+			if giveUp {
+				cancel()
+				return nil, lastErr
+			}
+		} else {
+			lastErr = nil
+			if giveUp {
+				// Leave the body open: the caller (go-github) still needs
+				// to read it to decode the real error message GitHub sent.
+				resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+				return resp, nil
+			}
+		}
+
+		var wait time.Duration
+		if err != nil {
+			wait = backoffDelay(attempt, rt.baseDelay, rt.maxDelay)
+		} else if d, ok := rateLimitWait(resp); ok {
+			wait = d
+		} else {
+			wait = backoffDelay(attempt, rt.baseDelay, rt.maxDelay)
+		}
+
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body) //nolint:errcheck
+			resp.Body.Close()
+		}
+		cancel()
+
+		log.Debug().
+			Int("attempt", attempt+1).
+			Dur("wait", wait).
+			Str("url", req.URL.String()).
+			Msg("Retrying GitHub API request")
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetryResponse reports whether resp indicates a transient failure or
+// a rate limit that's worth waiting out, rather than a final answer.
+func shouldRetryResponse(resp *http.Response) bool {
+	if resp.StatusCode >= 500 {
+		return true
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if resp.StatusCode == http.StatusForbidden {
+		if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			return true
+		}
+		if resp.Header.Get("Retry-After") != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// rateLimitWait returns how long to sleep before retrying resp, honoring
+// GitHub's primary rate limit (X-RateLimit-Remaining: 0, wait until
+// X-RateLimit-Reset) and secondary rate limit (Retry-After, in seconds) as
+// documented at
+// https://docs.github.com/en/rest/using-the-rest-api/rate-limits-for-the-rest-api
+func rateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if secs, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(secs) * time.Second, true
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset := resp.Header.Get("X-RateLimit-Reset"); reset != "" {
+			if ts, err := strconv.ParseInt(reset, 10, 64); err == nil {
+				wait := time.Until(time.Unix(ts, 0))
+				if wait > 0 {
+					return wait, true
+				}
+				return 0, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+// backoffDelay returns an exponential backoff delay for the given zero-based
+// attempt number, capped at maxDelay and with up to 50% jitter added so that
+// many clients retrying at once don't all hammer the API in lockstep.
+func backoffDelay(attempt int, base, maxDelay time.Duration) time.Duration {
+	delay := base << attempt
+	if delay <= 0 || delay > maxDelay {
+		delay = maxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay)/2 + 1)) //nolint:gosec
+	return delay + jitter
+}
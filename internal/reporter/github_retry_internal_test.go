@@ -0,0 +1,55 @@
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryTransportLeavesBodyOpenOnGiveUp(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"message":"boom"}`))
+	}))
+	defer srv.Close()
+
+	rt := &retryTransport{maxRetries: 1, baseDelay: time.Millisecond, maxDelay: time.Millisecond}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error building request: %s", err)
+	}
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("expected the response body to still be readable after giving up, got: %s", err)
+	}
+	if !bytes.Contains(body, []byte("boom")) {
+		t.Errorf("expected the original error body to survive past the give-up path, got: %s", body)
+	}
+}
+
+func TestAttemptContextSplitsRemainingBudget(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancel()
+
+	ctx, attemptCancel := attemptContext(parent, 0, 3)
+	defer attemptCancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected the attempt context to carry a deadline")
+	}
+	if remaining := time.Until(deadline); remaining >= 400*time.Millisecond {
+		t.Errorf("expected the first of several attempts to get less than the full budget, got %s", remaining)
+	}
+}
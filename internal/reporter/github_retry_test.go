@@ -0,0 +1,168 @@
+package reporter_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/git"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/reporter"
+	"github.com/rs/zerolog"
+)
+
+func TestGithubReporterRetries(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+- record: target is down
+  expr: up == 0
+`))
+
+	summary := reporter.Summary{
+		Reports: []reporter.Report{
+			{
+				Path: "foo.txt",
+				Rule: mockRules[0],
+				Problem: checks.Problem{
+					Fragment: "up == 0",
+					Lines:    []int{2},
+					Reporter: "mock",
+					Text:     "syntax error",
+					Severity: checks.Fatal,
+				},
+			},
+		},
+		FileChanges: discovery.NewFileCommitsFromMap(map[string][]string{"foo.txt": {"fake-commit-id"}}),
+	}
+
+	gitCmd := git.CommandRunner(func(args ...string) ([]byte, error) {
+		if args[0] == "rev-parse" {
+			return []byte("fake-commit-id"), nil
+		}
+		if args[0] == "blame" {
+			return []byte(blameLine("fake-commit-id", 2, "foo.txt", "up == 0")), nil
+		}
+		return nil, nil
+	})
+
+	t.Run("recovers from secondary rate limit", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) <= 2 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("{}"))
+		}))
+		defer srv.Close()
+
+		gr := reporter.NewGithubReporter(
+			&srv.URL, &srv.URL, 2*time.Second, "token", "foo", "bar", 123, gitCmd,
+			reporter.WithMaxRetries(5),
+			reporter.WithRetryBaseDelay(time.Millisecond),
+			reporter.WithRetryMaxDelay(5*time.Millisecond),
+		)
+
+		start := time.Now()
+		if err := gr.Submit(summary); err != nil {
+			t.Fatalf("expected the reporter to eventually succeed, got: %s", err)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("expected the call to stay within the deadline, took %s", elapsed)
+		}
+		if calls < 3 {
+			t.Errorf("expected at least 3 requests, got %d", calls)
+		}
+	})
+
+	t.Run("recovers from primary rate limit reset", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) <= 1 {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("{}"))
+		}))
+		defer srv.Close()
+
+		gr := reporter.NewGithubReporter(
+			&srv.URL, &srv.URL, 2*time.Second, "token", "foo", "bar", 123, gitCmd,
+			reporter.WithMaxRetries(5),
+			reporter.WithRetryBaseDelay(time.Millisecond),
+			reporter.WithRetryMaxDelay(5*time.Millisecond),
+		)
+
+		if err := gr.Submit(summary); err != nil {
+			t.Fatalf("expected the reporter to eventually succeed, got: %s", err)
+		}
+		if calls < 2 {
+			t.Errorf("expected at least 2 requests, got %d", calls)
+		}
+	})
+
+	t.Run("gives up after MaxRetries 5xx responses", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+
+		gr := reporter.NewGithubReporter(
+			&srv.URL, &srv.URL, 2*time.Second, "token", "foo", "bar", 123, gitCmd,
+			reporter.WithMaxRetries(2),
+			reporter.WithRetryBaseDelay(time.Millisecond),
+			reporter.WithRetryMaxDelay(5*time.Millisecond),
+		)
+
+		if err := gr.Submit(summary); err == nil {
+			t.Fatal("expected an error after exhausting retries")
+		}
+		if calls != 3 {
+			t.Errorf("expected 3 requests (1 initial + 2 retries), got %d", calls)
+		}
+	})
+
+	t.Run("a hanging attempt doesn't consume the whole deadline", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) <= 2 {
+				time.Sleep(500 * time.Millisecond)
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("{}"))
+		}))
+		defer srv.Close()
+
+		overallTimeout := 2 * time.Second
+		gr := reporter.NewGithubReporter(
+			&srv.URL, &srv.URL, overallTimeout, "token", "foo", "bar", 123, gitCmd,
+			reporter.WithMaxRetries(4),
+			reporter.WithRetryBaseDelay(time.Millisecond),
+			reporter.WithRetryMaxDelay(5*time.Millisecond),
+		)
+
+		start := time.Now()
+		if err := gr.Submit(summary); err != nil {
+			t.Fatalf("expected the reporter to eventually succeed once the server stops hanging, got: %s", err)
+		}
+		if elapsed := time.Since(start); elapsed >= overallTimeout {
+			t.Errorf("expected per-attempt timeouts to leave room for a retry within the overall deadline, took %s", elapsed)
+		}
+		if calls < 3 {
+			t.Errorf("expected at least 3 attempts (2 that time out on their per-attempt budget), got %d", calls)
+		}
+	})
+}
@@ -0,0 +1,240 @@
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/cloudflare/pint/internal/git"
+)
+
+// gitlabNoteMarker is embedded in every note body posted by GitLabReporter so
+// re-runs can tell which discussions were already left by pint.
+const gitlabNoteMarker = "<!-- pint:gitlab-reporter -->"
+
+type gitlabPosition struct {
+	BaseSHA      string `json:"base_sha"`
+	StartSHA     string `json:"start_sha"`
+	HeadSHA      string `json:"head_sha"`
+	PositionType string `json:"position_type"`
+	NewPath      string `json:"new_path"`
+	NewLine      int    `json:"new_line"`
+}
+
+type gitlabDiscussionRequest struct {
+	Body     string         `json:"body"`
+	Position gitlabPosition `json:"position"`
+}
+
+type gitlabNote struct {
+	Body string `json:"body"`
+}
+
+type gitlabDiscussion struct {
+	Notes []gitlabNote `json:"notes"`
+}
+
+// gitlabDiffRefs mirrors the diff_refs object GitLab returns for a merge
+// request, the base/start/head commits its own UI anchors diff positions to.
+type gitlabDiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	StartSHA string `json:"start_sha"`
+	HeadSHA  string `json:"head_sha"`
+}
+
+type gitlabMergeRequest struct {
+	DiffRefs gitlabDiffRefs `json:"diff_refs"`
+}
+
+// GitLabReporter submits check results as inline discussions on a GitLab
+// merge request, one discussion per problem. Each discussion's position is
+// anchored to the merge request's own diff_refs, not to the commit that last
+// touched the line; git blame is only used to skip lines it can't resolve.
+type GitLabReporter struct {
+	baseURL string
+	timeout time.Duration
+	token   string
+	project string
+	mrIID   int
+	gitCmd  git.CommandRunner
+	http    *http.Client
+}
+
+// NewGitLabReporter creates a new GitLabReporter that posts discussions
+// against the merge request mrIID on project (numeric ID or URL-encoded
+// path, as accepted by the GitLab API).
+func NewGitLabReporter(baseURL string, timeout time.Duration, token, project string, mrIID int, gitCmd git.CommandRunner) *GitLabReporter {
+	return &GitLabReporter{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		timeout: timeout,
+		token:   token,
+		project: project,
+		mrIID:   mrIID,
+		gitCmd:  gitCmd,
+		http:    &http.Client{},
+	}
+}
+
+func (gl GitLabReporter) apiURL(format string, args ...interface{}) string {
+	return gl.baseURL + "/api/v4/projects/" + url.PathEscape(gl.project) + fmt.Sprintf(format, args...)
+}
+
+func (gl GitLabReporter) do(ctx context.Context, method, path string, body any) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(buf)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", gl.token)
+	req.Header.Set("Content-Type", "application/json")
+	return gl.http.Do(req)
+}
+
+// diffRefs fetches the merge request's own diff_refs, the base/start/head
+// commits GitLab expects a discussion position to be anchored to. These are
+// not the same as the repo's current HEAD: start_sha/base_sha identify the
+// diff the MR is reviewed against, and must be identical across every
+// discussion posted in a run.
+func (gl GitLabReporter) diffRefs(ctx context.Context) (gitlabDiffRefs, error) {
+	resp, err := gl.do(ctx, http.MethodGet, gl.apiURL("/merge_requests/%d", gl.mrIID), nil)
+	if err != nil {
+		return gitlabDiffRefs{}, fmt.Errorf("fetching merge request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return gitlabDiffRefs{}, fmt.Errorf("fetching merge request: unexpected status %s", resp.Status)
+	}
+
+	var mr gitlabMergeRequest
+	if err := json.NewDecoder(resp.Body).Decode(&mr); err != nil {
+		return gitlabDiffRefs{}, fmt.Errorf("fetching merge request: %w", err)
+	}
+	return mr.DiffRefs, nil
+}
+
+// existingNotes returns the set of already-posted pint note bodies, so a
+// re-run doesn't spam the merge request with duplicate discussions. GitLab
+// paginates the discussions endpoint (20 per page by default), so this
+// follows X-Next-Page until the API reports there's nothing left to fetch.
+func (gl GitLabReporter) existingNotes(ctx context.Context) (map[string]struct{}, error) {
+	seen := make(map[string]struct{})
+
+	for page := 1; page != 0; {
+		resp, err := gl.do(ctx, http.MethodGet, gl.apiURL("/merge_requests/%d/discussions?page=%d&per_page=100", gl.mrIID, page), nil)
+		if err != nil {
+			return nil, fmt.Errorf("listing discussions: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("listing discussions: unexpected status %s", resp.Status)
+		}
+
+		var discussions []gitlabDiscussion
+		decodeErr := json.NewDecoder(resp.Body).Decode(&discussions)
+		nextPage := resp.Header.Get("X-Next-Page")
+		resp.Body.Close()
+		if decodeErr != nil {
+			return nil, fmt.Errorf("listing discussions: %w", decodeErr)
+		}
+
+		for _, d := range discussions {
+			for _, n := range d.Notes {
+				if strings.Contains(n.Body, gitlabNoteMarker) {
+					seen[n.Body] = struct{}{}
+				}
+			}
+		}
+
+		page = 0
+		if nextPage != "" {
+			if next, err := strconv.Atoi(nextPage); err == nil {
+				page = next
+			}
+		}
+	}
+
+	return seen, nil
+}
+
+// Name implements Reporter.
+func (gl GitLabReporter) Name() string {
+	return "gitlab"
+}
+
+// Submit posts one discussion per problem in the summary, positioned on the
+// merge request's diff_refs, skipping any problem whose line git blame can't
+// resolve and any problem that was already reported by a previous pint run.
+func (gl GitLabReporter) Submit(summary Summary) error {
+	ctx, cancel := context.WithTimeout(context.Background(), gl.timeout)
+	defer cancel()
+
+	refs, err := gl.diffRefs(ctx)
+	if err != nil {
+		return err
+	}
+
+	seen, err := gl.existingNotes(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, report := range summary.Reports {
+		if len(report.Problem.Lines) == 0 {
+			continue
+		}
+		blame, err := gl.gitCmd("blame", "--line-porcelain", report.Path)
+		if err != nil {
+			log.Error().Err(err).Str("path", report.Path).Msg("Failed to blame file, skipping comment")
+			continue
+		}
+		shas := blameSHAs(blame)
+		line := report.Problem.Lines[len(report.Problem.Lines)-1]
+		if _, ok := shas[line]; !ok {
+			continue
+		}
+
+		body := gitlabNoteMarker + "\n" + commentBody(report)
+		if _, ok := seen[body]; ok {
+			continue
+		}
+
+		discussion := gitlabDiscussionRequest{
+			Body: body,
+			Position: gitlabPosition{
+				BaseSHA:      refs.BaseSHA,
+				StartSHA:     refs.StartSHA,
+				HeadSHA:      refs.HeadSHA,
+				PositionType: "text",
+				NewPath:      report.Path,
+				NewLine:      line,
+			},
+		}
+		resp, err := gl.do(ctx, http.MethodPost, gl.apiURL("/merge_requests/%d/discussions", gl.mrIID), discussion)
+		if err != nil {
+			return fmt.Errorf("creating discussion: %w", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusCreated {
+			return fmt.Errorf("creating discussion: unexpected status %s", resp.Status)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,323 @@
+package reporter_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/git"
+	"github.com/cloudflare/pint/internal/parser"
+	"github.com/cloudflare/pint/internal/reporter"
+	"github.com/rs/zerolog"
+)
+
+func TestGitLabReporter(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	type errorCheck func(t *testing.T, err error) error
+
+	type testCaseT struct {
+		description  string
+		summary      reporter.Summary
+		httpHandler  http.Handler
+		errorHandler errorCheck
+		gitCmd       git.CommandRunner
+
+		token   string
+		project string
+		mrIID   int
+		timeout time.Duration
+	}
+
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+- record: target is down
+  expr: up == 0
+- record: sum errors
+  expr: sum(errors) by (job)
+`))
+
+	gitCmd := func(args ...string) ([]byte, error) {
+		if args[0] == "rev-parse" {
+			return []byte("fake-commit-id"), nil
+		}
+		if args[0] == "blame" {
+			return []byte(blameLine("0123456789abcdef0123456789abcdef01234567", 2, "foo.txt", "up == 0")), nil
+		}
+		return nil, nil
+	}
+
+	summary := reporter.Summary{
+		Reports: []reporter.Report{
+			{
+				Path: "foo.txt",
+				Rule: mockRules[1],
+				Problem: checks.Problem{
+					Fragment: "syntax error",
+					Lines:    []int{2},
+					Reporter: "mock",
+					Text:     "syntax error",
+					Severity: checks.Fatal,
+				},
+			},
+		},
+		FileChanges: discovery.NewFileCommitsFromMap(map[string][]string{"foo.txt": {"fake-commit-id"}}),
+	}
+
+	for _, tcase := range []testCaseT{
+		{
+			description: "timeout errors out",
+			token:       "something",
+			project:     "foo/bar",
+			mrIID:       123,
+			timeout:     100 * time.Millisecond,
+			gitCmd:      gitCmd,
+			httpHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(1 * time.Second)
+				_, _ = w.Write([]byte("[]"))
+			}),
+			errorHandler: func(t *testing.T, err error) error {
+				if err == nil {
+					return fmt.Errorf("expected an error")
+				}
+				return nil
+			},
+			summary: summary,
+		},
+		{
+			description: "happy path",
+			token:       "something",
+			project:     "foo/bar",
+			mrIID:       123,
+			timeout:     1000 * time.Millisecond,
+			gitCmd:      gitCmd,
+			errorHandler: func(t *testing.T, err error) error {
+				return err
+			},
+			summary: summary,
+		},
+		{
+			description: "skips duplicate discussions",
+			token:       "something",
+			project:     "foo/bar",
+			mrIID:       123,
+			timeout:     1000 * time.Millisecond,
+			gitCmd:      gitCmd,
+			httpHandler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/discussions"):
+					notes := []map[string]any{
+						{"notes": []map[string]any{
+							{"body": "<!-- pint:gitlab-reporter -->\nFatal error/mock: **syntax error**"},
+						}},
+					}
+					_ = json.NewEncoder(w).Encode(notes)
+				case r.Method == http.MethodGet:
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"diff_refs": map[string]string{
+							"base_sha": "base-sha", "start_sha": "start-sha", "head_sha": "mr-head-sha",
+						},
+					})
+				default:
+					t.Fatal("expected no POST request for an already reported problem")
+				}
+			}),
+			errorHandler: func(t *testing.T, err error) error {
+				return err
+			},
+			summary: summary,
+		},
+	} {
+		t.Run(tcase.description, func(t *testing.T) {
+			var handler http.Handler
+			if tcase.httpHandler != nil {
+				handler = tcase.httpHandler
+			} else {
+				handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					token := r.Header.Get("PRIVATE-TOKEN")
+					if token != tcase.token {
+						w.WriteHeader(500)
+						_, _ = w.Write([]byte("Invalid token"))
+						t.Fatalf("got a request with invalid token (got %s)", token)
+						return
+					}
+					switch {
+					case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/discussions"):
+						_, _ = w.Write([]byte("[]"))
+					case r.Method == http.MethodGet:
+						_ = json.NewEncoder(w).Encode(map[string]any{
+							"diff_refs": map[string]string{
+								"base_sha": "base-sha", "start_sha": "start-sha", "head_sha": "mr-head-sha",
+							},
+						})
+					case r.Method == http.MethodPost:
+						w.WriteHeader(http.StatusCreated)
+						_, _ = w.Write([]byte("{}"))
+					}
+				})
+			}
+			srv := httptest.NewServer(handler)
+			defer srv.Close()
+
+			gl := reporter.NewGitLabReporter(
+				srv.URL,
+				tcase.timeout,
+				tcase.token,
+				tcase.project,
+				tcase.mrIID,
+				tcase.gitCmd,
+			)
+
+			err := gl.Submit(tcase.summary)
+			if e := tcase.errorHandler(t, err); e != nil {
+				t.Errorf("error check failure: %s", e)
+				return
+			}
+		})
+	}
+}
+
+func TestGitLabReporterPositionSHAs(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+- record: target is down
+  expr: up == 0
+`))
+	summary := reporter.Summary{
+		Reports: []reporter.Report{
+			{
+				Path: "foo.txt",
+				Rule: mockRules[0],
+				Problem: checks.Problem{
+					Fragment: "up == 0",
+					Lines:    []int{2},
+					Reporter: "mock",
+					Text:     "syntax error",
+					Severity: checks.Fatal,
+				},
+			},
+		},
+		FileChanges: discovery.NewFileCommitsFromMap(map[string][]string{"foo.txt": {"fake-commit-id"}}),
+	}
+
+	var posted map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/discussions"):
+			_, _ = w.Write([]byte("[]"))
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"diff_refs": map[string]string{
+					"base_sha": "mr-base-sha", "start_sha": "mr-start-sha", "head_sha": "mr-head-sha",
+				},
+			})
+		case r.Method == http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&posted)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte("{}"))
+		}
+	}))
+	defer srv.Close()
+
+	gl := reporter.NewGitLabReporter(srv.URL, time.Second, "token", "foo/bar", 123, func(args ...string) ([]byte, error) {
+		if args[0] == "blame" {
+			return []byte(blameLine("0123456789abcdef0123456789abcdef01234567", 2, "foo.txt", "up == 0")), nil
+		}
+		return nil, nil
+	})
+
+	if err := gl.Submit(summary); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	position, _ := posted["position"].(map[string]any)
+	if position == nil {
+		t.Fatal("expected a position to be posted")
+	}
+	// base_sha/start_sha/head_sha must all come from the same diff_refs
+	// snapshot GitLab returns for the MR, never mixed with the local
+	// checkout's HEAD or a per-line commit from git blame.
+	if position["head_sha"] != "mr-head-sha" {
+		t.Errorf("expected head_sha to come from the MR's diff_refs, got %v", position["head_sha"])
+	}
+	if position["base_sha"] != "mr-base-sha" {
+		t.Errorf("expected base_sha to come from the MR's diff_refs, got %v", position["base_sha"])
+	}
+	if position["start_sha"] != "mr-start-sha" {
+		t.Errorf("expected start_sha to come from the MR's diff_refs, got %v", position["start_sha"])
+	}
+}
+
+func TestGitLabReporterPaginatesExistingDiscussions(t *testing.T) {
+	zerolog.SetGlobalLevel(zerolog.FatalLevel)
+
+	p := parser.NewParser()
+	mockRules, _ := p.Parse([]byte(`
+- record: target is down
+  expr: up == 0
+`))
+	summary := reporter.Summary{
+		Reports: []reporter.Report{
+			{
+				Path: "foo.txt",
+				Rule: mockRules[0],
+				Problem: checks.Problem{
+					Fragment: "up == 0",
+					Lines:    []int{2},
+					Reporter: "mock",
+					Text:     "syntax error",
+					Severity: checks.Fatal,
+				},
+			},
+		},
+		FileChanges: discovery.NewFileCommitsFromMap(map[string][]string{"foo.txt": {"fake-commit-id"}}),
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/discussions"):
+			if r.URL.Query().Get("page") == "2" {
+				notes := []map[string]any{
+					{"notes": []map[string]any{
+						{"body": "<!-- pint:gitlab-reporter -->\nFatal error/mock: **syntax error**"},
+					}},
+				}
+				_ = json.NewEncoder(w).Encode(notes)
+				return
+			}
+			w.Header().Set("X-Next-Page", "2")
+			_, _ = w.Write([]byte("[]"))
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"diff_refs": map[string]string{
+					"base_sha": "base-sha", "start_sha": "start-sha", "head_sha": "mr-head-sha",
+				},
+			})
+		case r.Method == http.MethodPost:
+			t.Fatal("expected no POST request for a problem already reported on a later discussions page")
+		}
+	}))
+	defer srv.Close()
+
+	gl := reporter.NewGitLabReporter(srv.URL, time.Second, "token", "foo/bar", 123, func(args ...string) ([]byte, error) {
+		if args[0] == "rev-parse" {
+			return []byte("fake-commit-id"), nil
+		}
+		if args[0] == "blame" {
+			return []byte(blameLine("0123456789abcdef0123456789abcdef01234567", 2, "foo.txt", "up == 0")), nil
+		}
+		return nil, nil
+	})
+
+	if err := gl.Submit(summary); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
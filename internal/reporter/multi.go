@@ -0,0 +1,90 @@
+package reporter
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cloudflare/pint/internal/checks"
+)
+
+// MultiReporter fans a single Summary out to several Reporters concurrently,
+// the substrate for configuring e.g. `reporters: [github, github-actions]`
+// in .pint.hcl so one pint run can post a PR review and emit log annotations
+// at the same time.
+type MultiReporter struct {
+	reporters []Reporter
+}
+
+// NewMultiReporter creates a MultiReporter that submits to every reporter in
+// reporters. Submissions run concurrently, so the order in which the reporters
+// are called is not defined; errors are combined in the order given.
+func NewMultiReporter(reporters ...Reporter) *MultiReporter {
+	return &MultiReporter{reporters: reporters}
+}
+
+// Name implements Reporter.
+func (mr MultiReporter) Name() string {
+	return "multi"
+}
+
+// Submit calls Submit on every configured reporter concurrently. A failing
+// reporter doesn't stop the others from submitting; all errors are combined
+// into the returned error via errors.Join, so a partial failure is still
+// reported but doesn't mask successes.
+func (mr MultiReporter) Submit(summary Summary) error {
+	errs := make([]error, len(mr.reporters))
+
+	var wg sync.WaitGroup
+	for i, r := range mr.reporters {
+		wg.Add(1)
+		go func(i int, r Reporter) {
+			defer wg.Done()
+			if err := r.Submit(summary); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", r.Name(), err)
+			}
+		}(i, r)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// NoopReporter discards every Summary it's given. It's used for dry runs,
+// where pint should still run all its checks but not report anywhere.
+type NoopReporter struct{}
+
+// Name implements Reporter.
+func (NoopReporter) Name() string {
+	return "noop"
+}
+
+// Submit implements Reporter and always succeeds without doing anything.
+func (NoopReporter) Submit(_ Summary) error {
+	return nil
+}
+
+// FilterReporter wraps another Reporter and drops any Report below
+// MinSeverity before delegating to it, so e.g. Information-level problems
+// can still fail a pint run without cluttering a PR review.
+type FilterReporter struct {
+	Reporter    Reporter
+	MinSeverity checks.Severity
+}
+
+// Name implements Reporter.
+func (fr FilterReporter) Name() string {
+	return fr.Reporter.Name()
+}
+
+// Submit implements Reporter, delegating only the reports at or above
+// MinSeverity to the wrapped Reporter.
+func (fr FilterReporter) Submit(summary Summary) error {
+	filtered := Summary{FileChanges: summary.FileChanges}
+	for _, report := range summary.Reports {
+		if report.Problem.Severity >= fr.MinSeverity {
+			filtered.Reports = append(filtered.Reports, report)
+		}
+	}
+	return fr.Reporter.Submit(filtered)
+}
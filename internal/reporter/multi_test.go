@@ -0,0 +1,102 @@
+package reporter_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/reporter"
+)
+
+type mockReporter struct {
+	name    string
+	err     error
+	summary *reporter.Summary
+}
+
+func (mr *mockReporter) Name() string { return mr.name }
+
+func (mr *mockReporter) Submit(summary reporter.Summary) error {
+	mr.summary = &summary
+	return mr.err
+}
+
+func TestMultiReporter(t *testing.T) {
+	summary := reporter.Summary{
+		Reports: []reporter.Report{
+			{
+				Path: "foo.txt",
+				Problem: checks.Problem{
+					Reporter: "mock",
+					Text:     "syntax error",
+					Severity: checks.Fatal,
+				},
+			},
+		},
+	}
+
+	t.Run("fans out to every reporter", func(t *testing.T) {
+		a := &mockReporter{name: "a"}
+		b := &mockReporter{name: "b"}
+
+		mr := reporter.NewMultiReporter(a, b)
+		if err := mr.Submit(summary); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if a.summary == nil || b.summary == nil {
+			t.Fatal("expected both reporters to receive the summary")
+		}
+	})
+
+	t.Run("one failure doesn't stop the others", func(t *testing.T) {
+		a := &mockReporter{name: "a", err: errors.New("boom")}
+		b := &mockReporter{name: "b"}
+
+		mr := reporter.NewMultiReporter(a, b)
+		err := mr.Submit(summary)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if !strings.Contains(err.Error(), "a: boom") {
+			t.Errorf("expected the error to mention the failing reporter, got: %s", err)
+		}
+		if b.summary == nil {
+			t.Error("expected the second reporter to still be called")
+		}
+	})
+}
+
+func TestNoopReporter(t *testing.T) {
+	var nr reporter.NoopReporter
+	if err := nr.Submit(reporter.Summary{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if nr.Name() != "noop" {
+		t.Errorf("unexpected name: %s", nr.Name())
+	}
+}
+
+func TestFilterReporter(t *testing.T) {
+	summary := reporter.Summary{
+		Reports: []reporter.Report{
+			{Path: "foo.txt", Problem: checks.Problem{Reporter: "mock", Text: "bad", Severity: checks.Fatal}},
+			{Path: "foo.txt", Problem: checks.Problem{Reporter: "mock", Text: "fyi", Severity: checks.Information}},
+		},
+	}
+
+	inner := &mockReporter{name: "inner"}
+	fr := reporter.FilterReporter{Reporter: inner, MinSeverity: checks.Warning}
+	if err := fr.Submit(summary); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fr.Name() != "inner" {
+		t.Errorf("expected FilterReporter to delegate Name(), got %s", fr.Name())
+	}
+	if len(inner.summary.Reports) != 1 {
+		t.Fatalf("expected only the Fatal report to pass the filter, got %d reports", len(inner.summary.Reports))
+	}
+	if inner.summary.Reports[0].Problem.Severity != checks.Fatal {
+		t.Errorf("expected the surviving report to be Fatal, got %s", inner.summary.Reports[0].Problem.Severity)
+	}
+}
@@ -0,0 +1,62 @@
+package reporter
+
+import (
+	"github.com/cloudflare/pint/internal/checks"
+	"github.com/cloudflare/pint/internal/discovery"
+	"github.com/cloudflare/pint/internal/parser"
+)
+
+// Reporter submits a Summary of pint problems to some backend - a pull
+// request review, CI log annotations, a code-insights report, etc. Multiple
+// reporters can be combined with MultiReporter so a single pint run can
+// notify several backends at once.
+type Reporter interface {
+	// Name identifies the reporter in logs and error messages.
+	Name() string
+	// Submit reports the results in summary, returning an error if they
+	// couldn't be delivered.
+	Submit(summary Summary) error
+}
+
+// Report describes a single problem detected in a rule file, together with
+// enough context (path, parsed rule) for a Reporter to map it back to a
+// specific line of a diff.
+type Report struct {
+	Path    string
+	Rule    parser.Rule
+	Problem checks.Problem
+}
+
+// Summary is the full set of problems found during a single pint run, along
+// with the commits that touched each affected file, so that reporters can
+// decide which problems overlap with the parts of a diff a reviewer actually
+// cares about.
+type Summary struct {
+	Reports     []Report
+	FileChanges discovery.FileChangesList
+}
+
+// severityLabel renders a checks.Severity as the human readable prefix used
+// when formatting a Report as a review comment. All comment-posting
+// reporters share this mapping so a given problem reads the same way
+// regardless of which backend is configured.
+func severityLabel(s checks.Severity) string {
+	switch s {
+	case checks.Fatal:
+		return "Fatal error"
+	case checks.Bug:
+		return "Bug risk"
+	case checks.Warning:
+		return "Warning"
+	case checks.Information:
+		return "Information"
+	default:
+		return s.String()
+	}
+}
+
+// commentBody renders a Report as the body of a single inline review
+// comment, shared by every reporter that posts line comments.
+func commentBody(r Report) string {
+	return severityLabel(r.Problem.Severity) + "/" + r.Problem.Reporter + ": **" + r.Problem.Text + "**"
+}